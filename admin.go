@@ -0,0 +1,225 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Resource describes a single asset as returned by Cloudinary's admin API.
+type Resource struct {
+	PublicID     string `json:"public_id"`
+	Format       string `json:"format"`
+	Version      uint   `json:"version"`
+	ResourceType string `json:"resource_type"`
+	Type         string `json:"type"`
+	CreatedAt    string `json:"created_at"`
+	Size         int    `json:"bytes"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	URL          string `json:"url"`
+	SecureURL    string `json:"secure_url"`
+}
+
+// ListResourcesResult is the response of the ListResources admin call.
+type ListResourcesResult struct {
+	Resources  []Resource `json:"resources"`
+	NextCursor string     `json:"next_cursor"`
+}
+
+// Delete deletes a resource uploaded to Cloudinary. The resource type is
+// recovered from publicURL itself (e.g. ".../video/upload/..." deletes as
+// a video).
+func (s *Service) Delete(ctx context.Context, publicURL url.URL) error {
+	publicID, resourceType, err := publicIDFromURL(publicURL)
+	if err != nil {
+		return err
+	}
+	return s.DeleteByPublicID(ctx, publicID, resourceType)
+}
+
+// DeleteByPublicID destroys the resourceType resource identified by
+// publicID.
+func (s *Service) DeleteByPublicID(ctx context.Context, publicID string, resourceType ResourceType) error {
+	ctx, cancel := s.withCallTimeout(ctx)
+	defer cancel()
+
+	params := map[string]string{
+		"api_key":   s.apiKey,
+		"public_id": publicID,
+		"timestamp": strconv.FormatInt(time.Now().Unix(), 10),
+	}
+	params["signature"] = signParams(params, s.apiSecret)
+
+	uri := fmt.Sprintf("%s/%s/%s/destroy", baseUploadURL, s.cloudName, resourceType)
+	m, err := s.doAdminRequest(ctx, http.MethodPost, uri, params)
+	if err != nil {
+		return err
+	}
+	if result, ok := m["result"].(string); ok && result != "ok" {
+		return fmt.Errorf("cloudinary: destroy failed for %q: %s", publicID, result)
+	}
+	return nil
+}
+
+// Rename changes the public ID of an existing resourceType resource.
+func (s *Service) Rename(ctx context.Context, fromPublicID, toPublicID string, resourceType ResourceType) error {
+	ctx, cancel := s.withCallTimeout(ctx)
+	defer cancel()
+
+	params := map[string]string{
+		"api_key":        s.apiKey,
+		"from_public_id": fromPublicID,
+		"to_public_id":   toPublicID,
+		"timestamp":      strconv.FormatInt(time.Now().Unix(), 10),
+	}
+	params["signature"] = signParams(params, s.apiSecret)
+
+	uri := fmt.Sprintf("%s/%s/%s/rename", baseUploadURL, s.cloudName, resourceType)
+	_, err := s.doAdminRequest(ctx, http.MethodPost, uri, params)
+	return err
+}
+
+// ListResources lists the resourceType resources stored in the account,
+// most recently created first.
+func (s *Service) ListResources(ctx context.Context, resourceType ResourceType) (*ListResourcesResult, error) {
+	ctx, cancel := s.withCallTimeout(ctx)
+	defer cancel()
+
+	params := map[string]string{
+		"api_key":   s.apiKey,
+		"timestamp": strconv.FormatInt(time.Now().Unix(), 10),
+	}
+	params["signature"] = signParams(params, s.apiSecret)
+
+	uri := fmt.Sprintf("%s/resources/%s", s.adminURI.String(), resourceType)
+	m, err := s.doAdminRequest(ctx, http.MethodGet, uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListResourcesResult
+	if err := decodeInto(m, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetResource fetches the details Cloudinary holds for a single
+// resourceType resource.
+func (s *Service) GetResource(ctx context.Context, publicID string, resourceType ResourceType) (*Resource, error) {
+	ctx, cancel := s.withCallTimeout(ctx)
+	defer cancel()
+
+	params := map[string]string{
+		"api_key":   s.apiKey,
+		"timestamp": strconv.FormatInt(time.Now().Unix(), 10),
+	}
+	params["signature"] = signParams(params, s.apiSecret)
+
+	uri := fmt.Sprintf("%s/resources/%s/upload/%s", s.adminURI.String(), resourceType, publicID)
+	m, err := s.doAdminRequest(ctx, http.MethodGet, uri, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resource Resource
+	if err := decodeInto(m, &resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// doAdminRequest sends params, which the caller has already signed, in a
+// request against the Cloudinary API, decoding the JSON response body into a generic map. The request
+// carries no body state that can't be rebuilt from scratch, so it is
+// always safe to retry per the Service's retry policy.
+func (s *Service) doAdminRequest(ctx context.Context, method, uri string, params map[string]string) (map[string]interface{}, error) {
+	values := url.Values{}
+	for k, v := range params {
+		values.Set(k, v)
+	}
+
+	resp, err := s.doWithRetry(ctx, true, func(ctx context.Context) (*http.Request, func() error, error) {
+		var req *http.Request
+		var err error
+		if method == http.MethodGet {
+			req, err = http.NewRequestWithContext(ctx, method, uri+"?"+values.Encode(), nil)
+		} else {
+			req, err = http.NewRequestWithContext(ctx, method, uri, strings.NewReader(values.Encode()))
+			if err == nil {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		closer := func() error { return nil }
+		if req.Body != nil {
+			closer = req.Body.Close
+		}
+		return req, closer, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, newRateLimitError(resp)
+	}
+
+	return handleHTTPResponse(resp)
+}
+
+// publicIDFromURL recovers the public ID and resource type Cloudinary
+// assigned to a resource from one of its delivery URLs, e.g.
+// "https://res.cloudinary.com/<cloudName>/video/upload/v1234/folder/name.mp4"
+// yields "folder/name" and ResourceTypeVideo.
+func publicIDFromURL(u url.URL) (publicID string, resourceType ResourceType, err error) {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[2] != "upload" {
+		return "", "", errors.New("url must be of format https://res.cloudinary.com/<cloudName>/<resourceType>/upload/...")
+	}
+	resourceType = ResourceType(parts[1])
+
+	rest := parts[3:]
+	if len(rest) > 1 && isVersionSegment(rest[0]) {
+		rest = rest[1:]
+	}
+
+	publicID = strings.Join(rest, "/")
+	return strings.TrimSuffix(publicID, path.Ext(publicID)), resourceType, nil
+}
+
+// decodeInto re-marshals a generic JSON map and unmarshals it into a typed
+// destination, so admin responses can reuse handleHTTPResponse's error
+// handling while still giving callers a concrete type.
+func decodeInto(m map[string]interface{}, dest interface{}) error {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dest)
+}
+
+func isVersionSegment(s string) bool {
+	if !strings.HasPrefix(s, "v") {
+		return false
+	}
+	_, err := strconv.Atoi(s[1:])
+	return err == nil
+}
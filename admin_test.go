@@ -0,0 +1,49 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package cloudinary
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestPublicIDFromURL(t *testing.T) {
+	cases := []struct {
+		in               string
+		want             string
+		wantResourceType ResourceType
+	}{
+		{"https://res.cloudinary.com/demo/image/upload/v1234/folder/sample.png", "folder/sample", ResourceTypeImage},
+		{"https://res.cloudinary.com/demo/image/upload/sample.jpg", "sample", ResourceTypeImage},
+		{"https://res.cloudinary.com/demo/image/upload/v1/sample", "sample", ResourceTypeImage},
+		{"https://res.cloudinary.com/demo/video/upload/v1234/folder/sample.mp4", "folder/sample", ResourceTypeVideo},
+		{"https://res.cloudinary.com/demo/raw/upload/v1234/sample.pdf", "sample", ResourceTypeRaw},
+	}
+
+	for _, c := range cases {
+		u, err := url.Parse(c.in)
+		if err != nil {
+			t.Fatalf("bad test URL %q: %v", c.in, err)
+		}
+		got, resourceType, err := publicIDFromURL(*u)
+		if err != nil {
+			t.Fatalf("publicIDFromURL(%q) returned error: %v", c.in, err)
+		}
+		if got != c.want {
+			t.Errorf("publicIDFromURL(%q) = %q, want %q", c.in, got, c.want)
+		}
+		if resourceType != c.wantResourceType {
+			t.Errorf("publicIDFromURL(%q) resource type = %q, want %q", c.in, resourceType, c.wantResourceType)
+		}
+	}
+}
+
+func TestPublicIDFromURLRejectsWrongFormat(t *testing.T) {
+	u, _ := url.Parse("https://res.cloudinary.com/demo/raw/private/sample.pdf")
+	if _, _, err := publicIDFromURL(*u); err == nil {
+		t.Error("expected an error for a non .../upload/... URL")
+	}
+}
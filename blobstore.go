@@ -0,0 +1,74 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// Object describes a single stored blob, as returned by a Blobstore's Put,
+// Get and List methods.
+type Object struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// PutOptions configures an individual Blobstore.Put call.
+type PutOptions struct {
+	ContentType string
+}
+
+// ListOptions filters the results of a Blobstore.List call.
+type ListOptions struct {
+	Prefix string
+}
+
+// Blobstore is a minimal object-storage abstraction implemented by each
+// storage provider (Cloudinary, S3-compatible stores, the local
+// filesystem, ...). Application code depending on Blobstore can switch
+// backends via configuration without touching call sites.
+type Blobstore interface {
+	// Put stores the content of r under key, creating or overwriting it.
+	Put(ctx context.Context, key string, r io.Reader, opts PutOptions) (*Object, error)
+	// Get retrieves the blob stored under key. The caller must close the
+	// returned ReadCloser.
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	// Delete removes the blob stored under key.
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL that grants access to key. Providers with
+	// time-limited URLs make it expire after the given duration; providers
+	// without them ignore expires.
+	SignedURL(ctx context.Context, key string, expires time.Duration) (string, error)
+	// List returns the blobs whose key matches opts.
+	List(ctx context.Context, opts ListOptions) ([]Object, error)
+}
+
+// objectFromUploadResult adapts an UploadResult, as returned by a fresh
+// upload, to the provider-agnostic Object shape.
+func objectFromUploadResult(r *UploadResult) *Object {
+	return &Object{
+		Key:         r.PublicID,
+		Size:        int64(r.Size),
+		ContentType: r.ResourceType,
+		ETag:        r.Etag,
+	}
+}
+
+// objectFromResource adapts a Resource, as returned by the admin API, to
+// the provider-agnostic Object shape.
+func objectFromResource(r *Resource) Object {
+	return Object{
+		Key:         r.PublicID,
+		Size:        int64(r.Size),
+		ContentType: r.ResourceType,
+	}
+}
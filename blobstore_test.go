@@ -0,0 +1,25 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import "testing"
+
+func TestObjectFromUploadResult(t *testing.T) {
+	r := &UploadResult{PublicID: "id1", Size: 42, ResourceType: "image", Etag: "e1"}
+	obj := objectFromUploadResult(r)
+	if obj.Key != "id1" || obj.Size != 42 || obj.ContentType != "image" || obj.ETag != "e1" {
+		t.Errorf("objectFromUploadResult(%+v) = %+v", r, obj)
+	}
+}
+
+func TestObjectFromResource(t *testing.T) {
+	r := &Resource{PublicID: "id2", Size: 7, ResourceType: "raw"}
+	obj := objectFromResource(r)
+	if obj.Key != "id2" || obj.Size != 7 || obj.ContentType != "raw" {
+		t.Errorf("objectFromResource(%+v) = %+v", r, obj)
+	}
+}
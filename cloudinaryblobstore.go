@@ -0,0 +1,187 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CloudinaryBlobstore adapts a Service to the Blobstore interface, treating
+// a blob's key as its Cloudinary public ID. Service itself can't implement
+// Blobstore directly: it already has a Delete method with a different
+// signature, kept for backwards compatibility with existing callers.
+type CloudinaryBlobstore struct {
+	Service *Service
+	// ResourceType is used for every Put. It defaults to ResourceTypeAuto,
+	// letting Cloudinary detect image/video/raw from the content. "auto"
+	// is a valid value for uploads only: Cloudinary's destroy,
+	// resource-fetch, list and delivery-URL endpoints reject it, so Get,
+	// Delete and SignedURL instead use the concrete type Cloudinary
+	// resolved for the key the last time it was Put through this store.
+	// List has no per-key upload to resolve against, so it requires
+	// ResourceType to already be concrete (image, video or raw).
+	ResourceType ResourceType
+
+	mu            sync.Mutex
+	resourceTypes map[string]ResourceType
+}
+
+var _ Blobstore = (*CloudinaryBlobstore)(nil)
+
+// NewCloudinaryBlobstore returns a Blobstore backed by s.
+func NewCloudinaryBlobstore(s *Service) *CloudinaryBlobstore {
+	return &CloudinaryBlobstore{
+		Service:       s,
+		ResourceType:  ResourceTypeAuto,
+		resourceTypes: make(map[string]ResourceType),
+	}
+}
+
+func (b *CloudinaryBlobstore) putResourceType() ResourceType {
+	if b.ResourceType == "" {
+		return ResourceTypeAuto
+	}
+	return b.ResourceType
+}
+
+// rememberResourceType records the concrete resource type Cloudinary
+// resolved for key, so later Get, Delete and SignedURL calls can address
+// it without guessing.
+func (b *CloudinaryBlobstore) rememberResourceType(key string, resourceType ResourceType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.resourceTypes[key] = resourceType
+}
+
+// resourceTypeFor returns the concrete resource type to use for key on
+// Get, Delete and SignedURL: the type Cloudinary resolved when key was
+// last Put through this store, falling back to ResourceType if that's
+// already concrete. ResourceTypeAuto is only valid for uploads, so it's an
+// error if neither source yields a concrete type.
+func (b *CloudinaryBlobstore) resourceTypeFor(key string) (ResourceType, error) {
+	b.mu.Lock()
+	resourceType, ok := b.resourceTypes[key]
+	b.mu.Unlock()
+	if ok {
+		return resourceType, nil
+	}
+	switch b.ResourceType {
+	case ResourceTypeImage, ResourceTypeVideo, ResourceTypeRaw:
+		return b.ResourceType, nil
+	default:
+		return "", fmt.Errorf("cloudinary: resource type for key %q is unknown; Put it through this store first, or set a concrete ResourceType", key)
+	}
+}
+
+// listResourceType returns the concrete resource type List should query.
+func (b *CloudinaryBlobstore) listResourceType() (ResourceType, error) {
+	switch b.ResourceType {
+	case ResourceTypeImage, ResourceTypeVideo, ResourceTypeRaw:
+		return b.ResourceType, nil
+	default:
+		return "", fmt.Errorf("cloudinary: List requires a concrete ResourceType (image, video or raw), got %q", b.ResourceType)
+	}
+}
+
+// Put uploads r as key's public ID, overwriting any existing asset with
+// that ID.
+func (b *CloudinaryBlobstore) Put(ctx context.Context, key string, r io.Reader, opts PutOptions) (*Object, error) {
+	overwrite := true
+	result, err := b.Service.UploadImageFile(ctx, r, key, b.putResourceType(), UploadOptions{
+		PublicID:  key,
+		Overwrite: &overwrite,
+	})
+	if err != nil {
+		return nil, err
+	}
+	b.rememberResourceType(key, ResourceType(result.ResourceType))
+	return objectFromUploadResult(result), nil
+}
+
+// Get fetches the asset stored under key from Cloudinary's CDN.
+func (b *CloudinaryBlobstore) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	resourceType, err := b.resourceTypeFor(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resource, err := b.Service.GetResource(ctx, key, resourceType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, resource.SecureURL, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := b.Service.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, nil, newAPIError(resp, errorMessageFromBody(resp.Body))
+	}
+
+	object := objectFromResource(resource)
+	return resp.Body, &object, nil
+}
+
+// Delete destroys the asset stored under key.
+func (b *CloudinaryBlobstore) Delete(ctx context.Context, key string) error {
+	resourceType, err := b.resourceTypeFor(key)
+	if err != nil {
+		return err
+	}
+	return b.Service.DeleteByPublicID(ctx, key, resourceType)
+}
+
+// SignedURL returns key's delivery URL. Cloudinary delivery URLs aren't
+// time-limited the way pre-signed S3 URLs are, so expires is ignored;
+// access control is instead configured per-asset or per-account in
+// Cloudinary itself.
+func (b *CloudinaryBlobstore) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	resourceType, err := b.resourceTypeFor(key)
+	if err != nil {
+		return "", err
+	}
+	u, err := b.Service.BuildURL(key, resourceType)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// List returns the resources whose public ID starts with opts.Prefix.
+// Unlike Get, Delete and SignedURL, List has no key to resolve a resource
+// type against, so b.ResourceType must already be concrete.
+func (b *CloudinaryBlobstore) List(ctx context.Context, opts ListOptions) ([]Object, error) {
+	resourceType, err := b.listResourceType()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := b.Service.ListResources(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]Object, 0, len(result.Resources))
+	for i := range result.Resources {
+		if opts.Prefix != "" && !strings.HasPrefix(result.Resources[i].PublicID, opts.Prefix) {
+			continue
+		}
+		objects = append(objects, objectFromResource(&result.Resources[i]))
+	}
+	return objects, nil
+}
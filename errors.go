@@ -0,0 +1,111 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Sentinel errors categorizing the kind of failure a Cloudinary API call
+// returned. APIError wraps one of these so callers can branch on the
+// failure category with errors.Is, e.g.:
+//
+//	if errors.Is(err, cloudinary.ErrNotFound) { ... }
+//
+// Use errors.As(err, &apiErr) to additionally get at the status code and
+// the raw message Cloudinary sent back.
+var (
+	ErrBadRequest   = fmt.Errorf("cloudinary: bad request")
+	ErrUnauthorized = fmt.Errorf("cloudinary: unauthorized")
+	ErrNotFound     = fmt.Errorf("cloudinary: not found")
+	ErrRateLimited  = fmt.Errorf("cloudinary: rate limited")
+	ErrServer       = fmt.Errorf("cloudinary: server error")
+)
+
+// APIError is returned for any non-2xx response from Cloudinary's upload or
+// admin APIs, except a 429, which is returned as a *RateLimitError instead.
+// CloudinaryError and RequestID, when present, come from the
+// X-Cld-Error and X-Request-Id response headers.
+type APIError struct {
+	StatusCode      int
+	Message         string
+	CloudinaryError string
+	RequestID       string
+
+	kind error
+}
+
+func (e *APIError) Error() string {
+	s := fmt.Sprintf("cloudinary: %s (status %d)", e.Message, e.StatusCode)
+	if e.RequestID != "" {
+		s += fmt.Sprintf(" [request_id=%s]", e.RequestID)
+	}
+	return s
+}
+
+// Unwrap exposes the sentinel error matching this failure's category, so
+// errors.Is(err, ErrNotFound) and similar checks work.
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+// kindForStatus maps an HTTP status code to the sentinel error that best
+// describes it.
+func kindForStatus(code int) error {
+	switch {
+	case code == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrUnauthorized
+	case code == http.StatusNotFound:
+		return ErrNotFound
+	case code >= 500:
+		return ErrServer
+	case code >= 400:
+		return ErrBadRequest
+	default:
+		return nil
+	}
+}
+
+// errorMessageFromBody extracts the "message" Cloudinary puts in its JSON
+// error bodies, i.e. {"error":{"message":"..."}}. It returns "" if body is
+// nil or doesn't look like that shape, leaving newAPIError to fall back to
+// the HTTP status line.
+func errorMessageFromBody(body io.Reader) string {
+	if body == nil {
+		return ""
+	}
+	var parsed struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(body).Decode(&parsed); err != nil {
+		return ""
+	}
+	return parsed.Error.Message
+}
+
+// newAPIError builds an APIError from a non-2xx response and the message
+// Cloudinary reported, falling back to the HTTP status line when no
+// message could be extracted from the response body.
+func newAPIError(resp *http.Response, message string) *APIError {
+	if message == "" {
+		message = resp.Status
+	}
+	return &APIError{
+		StatusCode:      resp.StatusCode,
+		Message:         message,
+		CloudinaryError: resp.Header.Get("X-Cld-Error"),
+		RequestID:       resp.Header.Get("X-Request-Id"),
+		kind:            kindForStatus(resp.StatusCode),
+	}
+}
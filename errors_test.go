@@ -0,0 +1,92 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewAPIErrorMatchesSentinelByStatus(t *testing.T) {
+	cases := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusBadRequest, ErrBadRequest},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServer},
+		{http.StatusBadGateway, ErrServer},
+	}
+	for _, c := range cases {
+		resp := &http.Response{StatusCode: c.status, Header: http.Header{}, Status: http.StatusText(c.status)}
+		err := newAPIError(resp, "boom")
+		if !errors.Is(err, c.want) {
+			t.Errorf("status %d: expected errors.Is to match %v, got %v", c.status, c.want, err)
+		}
+	}
+}
+
+func TestAPIErrorFieldsAndAs(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Status:     "404 Not Found",
+		Header: http.Header{
+			"X-Cld-Error":  []string{"Resource not found"},
+			"X-Request-Id": []string{"req-123"},
+		},
+	}
+	err := error(newAPIError(resp, "Resource not found - missing"))
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected errors.As to match *APIError, got %T", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, http.StatusNotFound)
+	}
+	if apiErr.Message != "Resource not found - missing" {
+		t.Errorf("Message = %q, want %q", apiErr.Message, "Resource not found - missing")
+	}
+	if apiErr.CloudinaryError != "Resource not found" {
+		t.Errorf("CloudinaryError = %q, want %q", apiErr.CloudinaryError, "Resource not found")
+	}
+	if apiErr.RequestID != "req-123" {
+		t.Errorf("RequestID = %q, want %q", apiErr.RequestID, "req-123")
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected errors.Is(err, ErrNotFound) to be true")
+	}
+}
+
+func TestNewAPIErrorFallsBackToStatusWhenMessageMissing(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusInternalServerError, Status: "500 Internal Server Error", Header: http.Header{}}
+	err := newAPIError(resp, "")
+	if err.Message != resp.Status {
+		t.Errorf("Message = %q, want fallback to %q", err.Message, resp.Status)
+	}
+}
+
+func TestErrorMessageFromBody(t *testing.T) {
+	const body = `{"error":{"message":"Missing required parameter - public_id"}}`
+	got := errorMessageFromBody(strings.NewReader(body))
+	want := "Missing required parameter - public_id"
+	if got != want {
+		t.Errorf("errorMessageFromBody() = %q, want %q", got, want)
+	}
+}
+
+func TestRateLimitErrorMatchesErrRateLimited(t *testing.T) {
+	var err error = &RateLimitError{Remaining: 0}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Error("expected errors.Is(err, ErrRateLimited) to match a *RateLimitError")
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// UploadLargeFile uploads r, whose total size is size bytes, in chunks of
+// chunkSize bytes, using Cloudinary's chunked upload protocol
+// (X-Unique-Upload-Id + Content-Range: bytes start-end/total). Each chunk
+// is sent as its own multipart POST to .../upload_large, all sharing the
+// same unique upload ID; the response to the final chunk is the canonical
+// upload result. This is required for files too large for a single
+// request (roughly 100MB) and is the only way to upload video assets of
+// any size that exceed Cloudinary's plain upload limit.
+func (s *Service) UploadLargeFile(ctx context.Context, r io.Reader, size, chunkSize int64, resourceType ResourceType, opts UploadOptions) (result *UploadResult, err error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("cloudinary: chunkSize must be > 0")
+	}
+
+	ctx, cancel := s.withCallTimeout(ctx)
+	defer cancel()
+
+	uploadID, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	uri := fmt.Sprintf("%s/%s/%s/upload_large", baseUploadURL, s.cloudName, resourceType)
+
+	buf := make([]byte, chunkSize)
+	var offset int64
+	for offset < size {
+		n, err := io.ReadFull(r, buf)
+		if err == io.ErrUnexpectedEOF {
+			err = nil
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if n == 0 {
+			break
+		}
+
+		req, err := newRequest(uri, s.apiKey, s.apiSecret, opts)
+		if err != nil {
+			return nil, err
+		}
+		if err := req.addImageFileToRequest(bytes.NewReader(buf[:n]), ""); err != nil {
+			return nil, err
+		}
+
+		end := offset + int64(n) - 1
+		req.setHeader("X-Unique-Upload-Id", uploadID)
+		req.setHeader("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, end, size))
+
+		result, err = s.doRequest(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		offset += int64(n)
+	}
+
+	if offset != size {
+		return nil, fmt.Errorf("cloudinary: r yielded %d bytes, want declared size %d", offset, size)
+	}
+
+	return result, nil
+}
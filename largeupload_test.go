@@ -0,0 +1,95 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package cloudinary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// fakeRoundTripper records the headers of every request it sees and
+// replies with a canned UploadResult, so UploadLargeFile can be tested
+// without a real Cloudinary endpoint.
+type fakeRoundTripper struct {
+	uploadIDs     []string
+	contentRanges []string
+}
+
+func (f *fakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.uploadIDs = append(f.uploadIDs, req.Header.Get("X-Unique-Upload-Id"))
+	f.contentRanges = append(f.contentRanges, req.Header.Get("Content-Range"))
+
+	body, err := json.Marshal(map[string]interface{}{
+		"public_id":  "large_file",
+		"secure_url": "https://res.cloudinary.com/demo/video/upload/large_file",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
+
+func TestUploadLargeFileChunksAndReusesUploadID(t *testing.T) {
+	rt := &fakeRoundTripper{}
+	s := &Service{
+		client:    http.Client{Transport: rt},
+		cloudName: "demo",
+		apiKey:    "key",
+		apiSecret: "secret",
+	}
+
+	data := bytes.Repeat([]byte("a"), 25)
+	result, err := s.UploadLargeFile(context.Background(), bytes.NewReader(data), int64(len(data)), 10, ResourceTypeVideo, UploadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result == nil || result.SecureURL != "https://res.cloudinary.com/demo/video/upload/large_file" {
+		t.Errorf("unexpected result: %v", result)
+	}
+
+	if len(rt.uploadIDs) != 3 {
+		t.Fatalf("expected 3 chunked requests for a 25 byte file with chunkSize 10, got %d", len(rt.uploadIDs))
+	}
+	if rt.uploadIDs[0] == "" || rt.uploadIDs[0] != rt.uploadIDs[1] || rt.uploadIDs[1] != rt.uploadIDs[2] {
+		t.Errorf("expected the same X-Unique-Upload-Id across all parts, got %v", rt.uploadIDs)
+	}
+	want := []string{"bytes 0-9/25", "bytes 10-19/25", "bytes 20-24/25"}
+	for i, wantRange := range want {
+		if rt.contentRanges[i] != wantRange {
+			t.Errorf("part %d: Content-Range = %q, want %q", i, rt.contentRanges[i], wantRange)
+		}
+	}
+}
+
+func TestUploadLargeFileRejectsNonPositiveChunkSize(t *testing.T) {
+	s := &Service{cloudName: "demo", apiKey: "key", apiSecret: "secret"}
+	if _, err := s.UploadLargeFile(context.Background(), bytes.NewReader(nil), 0, 0, ResourceTypeRaw, UploadOptions{}); err == nil {
+		t.Error("expected an error for a non-positive chunkSize")
+	}
+}
+
+func TestUploadLargeFileRejectsShortReader(t *testing.T) {
+	rt := &fakeRoundTripper{}
+	s := &Service{
+		client:    http.Client{Transport: rt},
+		cloudName: "demo",
+		apiKey:    "key",
+		apiSecret: "secret",
+	}
+
+	data := bytes.Repeat([]byte("a"), 10)
+	if _, err := s.UploadLargeFile(context.Background(), bytes.NewReader(data), 100, 10, ResourceTypeVideo, UploadOptions{}); err == nil {
+		t.Error("expected an error when r yields fewer bytes than the declared size")
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. A request is
+// retried when it receives a 429 or 5xx response, or fails with a network
+// error, up to MaxRetries times, waiting between attempts according to
+// Retry-After when the server sends one, or an exponential backoff with
+// jitter bounded by BaseDelay and MaxDelay otherwise.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// defaultRetryPolicy is applied by Dial unless overridden with
+// WithRetryPolicy.
+func defaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 2,
+		BaseDelay:  250 * time.Millisecond,
+		MaxDelay:   5 * time.Second,
+	}
+}
+
+// ServiceOption configures optional behaviour of a Service at Dial time.
+type ServiceOption func(*Service)
+
+// WithHTTPClient replaces the http.Client used for all requests.
+func WithHTTPClient(client *http.Client) ServiceOption {
+	return func(s *Service) {
+		s.client = *client
+	}
+}
+
+// WithRoundTripper sets the RoundTripper used by the Service's http.Client,
+// e.g. to inject tracing, logging or a custom transport.
+func WithRoundTripper(rt http.RoundTripper) ServiceOption {
+	return func(s *Service) {
+		s.client.Transport = rt
+	}
+}
+
+// WithTimeout bounds the duration of each call made through the Service.
+// It is applied as a context.WithTimeout around the context passed to each
+// exported method, on top of whatever deadline that context already
+// carries.
+func WithTimeout(d time.Duration) ServiceOption {
+	return func(s *Service) {
+		s.callTimeout = d
+	}
+}
+
+// WithRetryPolicy overrides the default retry/backoff policy.
+func WithRetryPolicy(p RetryPolicy) ServiceOption {
+	return func(s *Service) {
+		s.retryPolicy = p
+	}
+}
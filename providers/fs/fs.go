@@ -0,0 +1,163 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fs implements cloudinary.Blobstore backed by the local
+// filesystem, for local development and tests that shouldn't need a real
+// object-storage account.
+package fs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tumelohq/go-cloudinary"
+)
+
+// Blobstore stores blobs as files under Root, one file per key.
+type Blobstore struct {
+	Root string
+}
+
+var _ cloudinary.Blobstore = (*Blobstore)(nil)
+
+// New returns a Blobstore rooted at root, creating it if it doesn't
+// already exist.
+func New(root string) (*Blobstore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &Blobstore{Root: root}, nil
+}
+
+// path resolves key to a file path under b.Root, rejecting keys that would
+// escape it (e.g. via "..").
+func (b *Blobstore) path(key string) (string, error) {
+	root := filepath.Clean(b.Root)
+	p := filepath.Join(root, filepath.FromSlash(key))
+	if p != root && !strings.HasPrefix(p, root+string(os.PathSeparator)) {
+		return "", fmt.Errorf("fs: key %q escapes the store root", key)
+	}
+	return p, nil
+}
+
+// Put writes r to the file for key, creating parent directories as
+// needed.
+func (b *Blobstore) Put(ctx context.Context, key string, r io.Reader, opts cloudinary.PutOptions) (*cloudinary.Object, error) {
+	p, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	size, err := io.Copy(f, r)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return &cloudinary.Object{
+		Key:          key,
+		Size:         size,
+		ContentType:  opts.ContentType,
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+// Get opens the file for key. The caller must close the returned
+// ReadCloser.
+func (b *Blobstore) Get(ctx context.Context, key string) (io.ReadCloser, *cloudinary.Object, error) {
+	p, err := b.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, cloudinary.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	return f, &cloudinary.Object{Key: key, Size: info.Size(), LastModified: info.ModTime()}, nil
+}
+
+// Delete removes the file for key.
+func (b *Blobstore) Delete(ctx context.Context, key string) error {
+	p, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cloudinary.ErrNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// SignedURL returns a file:// URL for key. There's no notion of expiry or
+// access control on the local filesystem, so expires is ignored.
+func (b *Blobstore) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	p, err := b.path(key)
+	if err != nil {
+		return "", err
+	}
+	return "file://" + p, nil
+}
+
+// List walks Root and returns every file whose key starts with
+// opts.Prefix.
+func (b *Blobstore) List(ctx context.Context, opts cloudinary.ListOptions) ([]cloudinary.Object, error) {
+	var objects []cloudinary.Object
+	err := filepath.Walk(b.Root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(b.Root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if opts.Prefix != "" && !strings.HasPrefix(key, opts.Prefix) {
+			return nil
+		}
+		objects = append(objects, cloudinary.Object{Key: key, Size: info.Size(), LastModified: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
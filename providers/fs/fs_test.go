@@ -0,0 +1,84 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/tumelohq/go-cloudinary"
+)
+
+func TestBlobstorePutGetDelete(t *testing.T) {
+	b, err := New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	data := []byte("hello world")
+
+	if _, err := b.Put(ctx, "folder/file.txt", bytes.NewReader(data), cloudinary.PutOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	rc, obj, err := b.Get(ctx, "folder/file.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Errorf("Get() body = %q, want %q", got, data)
+	}
+	if obj.Size != int64(len(data)) {
+		t.Errorf("Get() Size = %d, want %d", obj.Size, len(data))
+	}
+
+	if err := b.Delete(ctx, "folder/file.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := b.Get(ctx, "folder/file.txt"); !errors.Is(err, cloudinary.ErrNotFound) {
+		t.Errorf("Get() after Delete() = %v, want cloudinary.ErrNotFound", err)
+	}
+}
+
+func TestBlobstoreListFiltersByPrefix(t *testing.T) {
+	b, err := New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for _, key := range []string{"a/1.txt", "a/2.txt", "b/1.txt"} {
+		if _, err := b.Put(ctx, key, bytes.NewReader(nil), cloudinary.PutOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	objects, err := b.List(ctx, cloudinary.ListOptions{Prefix: "a/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 {
+		t.Errorf("List() returned %d objects, want 2: %v", len(objects), objects)
+	}
+}
+
+func TestBlobstoreRejectsKeysEscapingRoot(t *testing.T) {
+	b, err := New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Put(context.Background(), "../escape.txt", bytes.NewReader(nil), cloudinary.PutOptions{}); err == nil {
+		t.Error("expected an error for a key that escapes the store root")
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package multi implements cloudinary.Blobstore by fanning writes out to
+// several backing stores, for migrating between storage providers without
+// downtime: Put and Delete apply to every store, while Get, SignedURL and
+// List are served from the first ("primary") store.
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/tumelohq/go-cloudinary"
+)
+
+// Blobstore fans writes out to Stores, reading back from Stores[0].
+type Blobstore struct {
+	Stores []cloudinary.Blobstore
+}
+
+var _ cloudinary.Blobstore = (*Blobstore)(nil)
+
+// New returns a Blobstore that writes to every store in stores and reads
+// from stores[0]. At least one store is required.
+func New(stores ...cloudinary.Blobstore) (*Blobstore, error) {
+	if len(stores) == 0 {
+		return nil, errors.New("multi: at least one store is required")
+	}
+	return &Blobstore{Stores: stores}, nil
+}
+
+// Put spools r to a temporary file so its content can be replayed to each
+// store in turn, rather than buffering the whole blob in memory — large
+// video/raw uploads would otherwise defeat the streaming Service.Upload*
+// does at the single-store layer. It returns the Object reported by the
+// primary (first) store.
+func (b *Blobstore) Put(ctx context.Context, key string, r io.Reader, opts cloudinary.PutOptions) (*cloudinary.Object, error) {
+	spool, err := os.CreateTemp("", "cloudinary-multi-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	if _, err := io.Copy(spool, r); err != nil {
+		return nil, err
+	}
+
+	var primary *cloudinary.Object
+	for i, store := range b.Stores {
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		obj, err := store.Put(ctx, key, spool, opts)
+		if err != nil {
+			return nil, fmt.Errorf("multi: store %d: %w", i, err)
+		}
+		if i == 0 {
+			primary = obj
+		}
+	}
+	return primary, nil
+}
+
+// Get reads key from the primary store.
+func (b *Blobstore) Get(ctx context.Context, key string) (io.ReadCloser, *cloudinary.Object, error) {
+	return b.Stores[0].Get(ctx, key)
+}
+
+// Delete removes key from every store.
+func (b *Blobstore) Delete(ctx context.Context, key string) error {
+	for i, store := range b.Stores {
+		if err := store.Delete(ctx, key); err != nil {
+			return fmt.Errorf("multi: store %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// SignedURL returns a URL for key from the primary store.
+func (b *Blobstore) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return b.Stores[0].SignedURL(ctx, key, expires)
+}
+
+// List returns the primary store's listing.
+func (b *Blobstore) List(ctx context.Context, opts cloudinary.ListOptions) ([]cloudinary.Object, error) {
+	return b.Stores[0].List(ctx, opts)
+}
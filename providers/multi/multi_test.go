@@ -0,0 +1,83 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package multi
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/tumelohq/go-cloudinary"
+	"github.com/tumelohq/go-cloudinary/providers/fs"
+)
+
+func TestNewRejectsNoStores(t *testing.T) {
+	if _, err := New(); err == nil {
+		t.Error("expected an error when no stores are given")
+	}
+}
+
+func TestBlobstorePutFansOutToEveryStore(t *testing.T) {
+	a, err := fs.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bStore, err := fs.New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := New(a, bStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	data := []byte("migrate me")
+	if _, err := m.Put(ctx, "key", bytes.NewReader(data), cloudinary.PutOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, store := range map[string]cloudinary.Blobstore{"a": a, "b": bStore} {
+		rc, _, err := store.Get(ctx, "key")
+		if err != nil {
+			t.Fatalf("store %s: %v", name, err)
+		}
+		got, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("store %s: %v", name, err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Errorf("store %s: got %q, want %q", name, got, data)
+		}
+	}
+}
+
+func TestBlobstoreDeleteAppliesToEveryStore(t *testing.T) {
+	a, _ := fs.New(t.TempDir())
+	bStore, _ := fs.New(t.TempDir())
+	m, err := New(a, bStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := m.Put(ctx, "key", bytes.NewReader(nil), cloudinary.PutOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Delete(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, store := range map[string]cloudinary.Blobstore{"a": a, "b": bStore} {
+		if _, _, err := store.Get(ctx, "key"); err == nil {
+			t.Errorf("store %s: expected key to be deleted", name)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package s3 implements cloudinary.Blobstore against any S3-compatible
+// object store (AWS S3, MinIO, etc.) via the minio-go client.
+package s3
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/tumelohq/go-cloudinary"
+)
+
+// Config configures a new Blobstore.
+type Config struct {
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	Bucket          string
+	UseSSL          bool
+}
+
+// Blobstore stores blobs as objects in a single S3 bucket.
+type Blobstore struct {
+	client *minio.Client
+	bucket string
+}
+
+var _ cloudinary.Blobstore = (*Blobstore)(nil)
+
+// New connects to an S3-compatible endpoint and returns a Blobstore backed
+// by cfg.Bucket. The bucket must already exist.
+func New(cfg Config) (*Blobstore, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Blobstore{client: client, bucket: cfg.Bucket}, nil
+}
+
+// Put uploads r as an object named key.
+func (b *Blobstore) Put(ctx context.Context, key string, r io.Reader, opts cloudinary.PutOptions) (*cloudinary.Object, error) {
+	info, err := b.client.PutObject(ctx, b.bucket, key, r, -1, minio.PutObjectOptions{ContentType: opts.ContentType})
+	if err != nil {
+		return nil, err
+	}
+	return &cloudinary.Object{
+		Key:          key,
+		Size:         info.Size,
+		ContentType:  opts.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+// Get fetches the object named key. The caller must close the returned
+// ReadCloser.
+func (b *Blobstore) Get(ctx context.Context, key string) (io.ReadCloser, *cloudinary.Object, error) {
+	obj, err := b.client.GetObject(ctx, b.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := obj.Stat()
+	if err != nil {
+		obj.Close()
+		if resp := minio.ToErrorResponse(err); resp.Code == "NoSuchKey" {
+			return nil, nil, cloudinary.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	return obj, &cloudinary.Object{
+		Key:          key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+// Delete removes the object named key.
+func (b *Blobstore) Delete(ctx context.Context, key string) error {
+	return b.client.RemoveObject(ctx, b.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// SignedURL returns a pre-signed GET URL for key, valid for expires.
+func (b *Blobstore) SignedURL(ctx context.Context, key string, expires time.Duration) (string, error) {
+	u, err := b.client.PresignedGetObject(ctx, b.bucket, key, expires, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// List returns the objects whose key starts with opts.Prefix.
+func (b *Blobstore) List(ctx context.Context, opts cloudinary.ListOptions) ([]cloudinary.Object, error) {
+	var objects []cloudinary.Object
+	for obj := range b.client.ListObjects(ctx, b.bucket, minio.ListObjectsOptions{Prefix: opts.Prefix, Recursive: true}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		objects = append(objects, cloudinary.Object{
+			Key:          obj.Key,
+			Size:         obj.Size,
+			ETag:         obj.ETag,
+			LastModified: obj.LastModified,
+		})
+	}
+	return objects, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitError is returned when Cloudinary responds with 429 Too Many
+// Requests after the Service's retry policy has been exhausted. Remaining
+// and ResetAt are parsed from the X-RateLimit-Remaining/X-RateLimit-Reset
+// headers Cloudinary includes on every upload API response.
+type RateLimitError struct {
+	Remaining int
+	ResetAt   time.Time
+	Message   string
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("cloudinary: rate limited, %d requests remaining, resets at %s: %s", e.Remaining, e.ResetAt, e.Message)
+}
+
+// Is reports whether target is the ErrRateLimited sentinel, so
+// errors.Is(err, ErrRateLimited) matches a *RateLimitError the same way it
+// would match an *APIError for a 429 response.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// newRateLimitError builds a RateLimitError from a 429 response.
+func newRateLimitError(resp *http.Response) *RateLimitError {
+	remaining, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+
+	var resetAt time.Time
+	if reset := resp.Header.Get("X-RateLimit-Reset"); reset != "" {
+		if secs, err := strconv.ParseInt(reset, 10, 64); err == nil {
+			resetAt = time.Unix(secs, 0)
+		}
+	}
+
+	return &RateLimitError{
+		Remaining: remaining,
+		ResetAt:   resetAt,
+		Message:   resp.Status,
+	}
+}
+
+// retryAfter extracts the server-requested wait time from a Retry-After
+// header, which Cloudinary sends as either a number of seconds or an HTTP
+// date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
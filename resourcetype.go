@@ -0,0 +1,32 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import "fmt"
+
+// ResourceType identifies the kind of asset being uploaded to, or fetched
+// from, Cloudinary. It selects the endpoint path segment Cloudinary uses
+// to route the request, e.g. ".../image/upload/", ".../video/upload/".
+type ResourceType string
+
+const (
+	// ResourceTypeImage is for photos and other raster/vector images.
+	ResourceTypeImage ResourceType = "image"
+	// ResourceTypeVideo is for video (and audio) files.
+	ResourceTypeVideo ResourceType = "video"
+	// ResourceTypeRaw is for any other file type Cloudinary should store
+	// as-is, without media-specific processing.
+	ResourceTypeRaw ResourceType = "raw"
+	// ResourceTypeAuto lets Cloudinary detect the resource type from the
+	// uploaded content.
+	ResourceTypeAuto ResourceType = "auto"
+)
+
+// uploadURIFor builds the upload endpoint for the given resource type.
+func (s *Service) uploadURIFor(resourceType ResourceType) string {
+	return fmt.Sprintf("%s/%s/%s/upload/", baseUploadURL, s.cloudName, resourceType)
+}
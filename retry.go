@@ -0,0 +1,96 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// isRetryableStatus reports whether a response status code is worth
+// retrying: rate limiting and server-side failures, but never a 4xx
+// client error other than 429.
+func isRetryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
+
+// backoffDelay computes an exponential backoff with jitter for the given
+// attempt (0-indexed), bounded by the policy's MaxDelay.
+func backoffDelay(p RetryPolicy, attempt int) time.Duration {
+	d := p.BaseDelay << attempt
+	if d <= 0 || d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// sleep waits for d, returning false early if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// doWithRetry runs build to get an *http.Request and sends it, retrying
+// according to s.retryPolicy on network errors, 429s and 5xxs. build is
+// called again for every attempt since the request body may need to be
+// reconstructed; retryable reports whether doing so is safe (e.g. a
+// streamed file body can only be retried if it can be rewound).
+func (s *Service) doWithRetry(ctx context.Context, retryable bool, build func(ctx context.Context) (*http.Request, func() error, error)) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+
+	for attempt := 0; ; attempt++ {
+		var httpReq *http.Request
+		var closer func() error
+		httpReq, closer, err = build(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err = s.client.Do(httpReq)
+		if err != nil {
+			closer()
+			if !retryable || attempt >= s.retryPolicy.MaxRetries {
+				return nil, err
+			}
+			if !sleep(ctx, backoffDelay(s.retryPolicy, attempt)) {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+
+		if !retryable || attempt >= s.retryPolicy.MaxRetries || !isRetryableStatus(resp.StatusCode) {
+			return resp, nil
+		}
+
+		wait, hasRetryAfter := retryAfter(resp)
+		resp.Body.Close()
+		if !hasRetryAfter {
+			wait = backoffDelay(s.retryPolicy, attempt)
+		}
+		if !sleep(ctx, wait) {
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// withCallTimeout applies the Service's configured per-call timeout (if
+// any) on top of whatever deadline ctx already carries.
+func (s *Service) withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.callTimeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, s.callTimeout)
+}
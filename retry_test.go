@@ -0,0 +1,161 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package cloudinary
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// scriptedRoundTripper replies with the next response in a canned script
+// on each call, recording how many times it was invoked.
+type scriptedRoundTripper struct {
+	responses []func() (*http.Response, error)
+	calls     int
+}
+
+func (rt *scriptedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, err := io.Copy(io.Discard, req.Body); err != nil {
+		return nil, err
+	}
+	resp, err := rt.responses[rt.calls]()
+	rt.calls++
+	return resp, err
+}
+
+func jsonResponse(status int, body string, headers http.Header) *http.Response {
+	if headers == nil {
+		headers = http.Header{}
+	}
+	headers.Set("Content-Type", "application/json")
+	return &http.Response{
+		StatusCode: status,
+		Header:     headers,
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+	}
+}
+
+func TestUploadImageFileRetriesOn503ThenSucceeds(t *testing.T) {
+	rt := &scriptedRoundTripper{
+		responses: []func() (*http.Response, error){
+			func() (*http.Response, error) { return jsonResponse(http.StatusServiceUnavailable, `{}`, nil), nil },
+			func() (*http.Response, error) {
+				return jsonResponse(http.StatusOK, `{"public_id":"x","secure_url":"https://res.cloudinary.com/demo/image/upload/x"}`, nil), nil
+			},
+		},
+	}
+	s := &Service{
+		client:      http.Client{Transport: rt},
+		cloudName:   "demo",
+		apiKey:      "key",
+		apiSecret:   "secret",
+		retryPolicy: RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+	}
+
+	result, err := s.UploadImageFile(context.Background(), bytes.NewReader([]byte("data")), "", ResourceTypeImage, UploadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rt.calls != 2 {
+		t.Errorf("expected 2 calls (1 failure + 1 retry), got %d", rt.calls)
+	}
+	if result.SecureURL != "https://res.cloudinary.com/demo/image/upload/x" {
+		t.Errorf("unexpected result: %v", result)
+	}
+}
+
+func TestUploadImageFileGivesUpAfterMaxRetries(t *testing.T) {
+	rt := &scriptedRoundTripper{
+		responses: []func() (*http.Response, error){
+			func() (*http.Response, error) { return jsonResponse(http.StatusServiceUnavailable, `{}`, nil), nil },
+			func() (*http.Response, error) { return jsonResponse(http.StatusServiceUnavailable, `{}`, nil), nil },
+		},
+	}
+	s := &Service{
+		client:      http.Client{Transport: rt},
+		cloudName:   "demo",
+		apiKey:      "key",
+		apiSecret:   "secret",
+		retryPolicy: RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+	}
+
+	_, err := s.UploadImageFile(context.Background(), bytes.NewReader([]byte("data")), "", ResourceTypeImage, UploadOptions{})
+	if err == nil {
+		t.Fatal("expected an error once retries are exhausted")
+	}
+	if rt.calls != 2 {
+		t.Errorf("expected exactly 1 initial attempt + 1 retry, got %d calls", rt.calls)
+	}
+}
+
+func TestUploadImageFileReturnsRateLimitErrorAfterRetries(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Remaining", "0")
+	headers.Set("X-RateLimit-Reset", "1700000000")
+
+	rt := &scriptedRoundTripper{
+		responses: []func() (*http.Response, error){
+			func() (*http.Response, error) {
+				return jsonResponse(http.StatusTooManyRequests, `{}`, headers.Clone()), nil
+			},
+		},
+	}
+	s := &Service{
+		client:      http.Client{Transport: rt},
+		cloudName:   "demo",
+		apiKey:      "key",
+		apiSecret:   "secret",
+		retryPolicy: RetryPolicy{MaxRetries: 0, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+	}
+
+	_, err := s.UploadImageFile(context.Background(), bytes.NewReader([]byte("data")), "", ResourceTypeImage, UploadOptions{})
+	rlErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("expected a *RateLimitError, got %T: %v", err, err)
+	}
+	if rlErr.Remaining != 0 {
+		t.Errorf("Remaining = %d, want 0", rlErr.Remaining)
+	}
+	if rlErr.ResetAt.Unix() != 1700000000 {
+		t.Errorf("ResetAt = %v, want unix 1700000000", rlErr.ResetAt)
+	}
+}
+
+// slowRoundTripper respects request cancellation, the way a real transport
+// does, instead of blocking obliviously like scriptedRoundTripper.
+type slowRoundTripper struct{ delay time.Duration }
+
+func (rt *slowRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, err := io.Copy(io.Discard, req.Body); err != nil {
+		return nil, err
+	}
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case <-time.After(rt.delay):
+		return jsonResponse(http.StatusOK, `{}`, nil), nil
+	}
+}
+
+func TestWithCallTimeoutCancelsSlowRequests(t *testing.T) {
+	s := &Service{
+		client:      http.Client{Transport: &slowRoundTripper{delay: 50 * time.Millisecond}},
+		cloudName:   "demo",
+		apiKey:      "key",
+		apiSecret:   "secret",
+		callTimeout: time.Millisecond,
+		retryPolicy: RetryPolicy{MaxRetries: 0, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+	}
+
+	_, err := s.UploadImageFile(context.Background(), bytes.NewReader([]byte("data")), "", ResourceTypeImage, UploadOptions{})
+	if err == nil {
+		t.Fatal("expected the call timeout to cancel the request")
+	}
+}
@@ -5,6 +5,7 @@
 package cloudinary
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
@@ -55,11 +56,11 @@ func TestUploadByFile(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	id, err := s.UploadImageFile(f, "")
+	result, err := s.UploadImageFile(context.Background(), f, "", ResourceTypeImage, UploadOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	t.Log(id)
+	t.Log(result)
 }
 
 func TestUploadByURL(t *testing.T) {
@@ -73,9 +74,9 @@ func TestUploadByURL(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	id, err := s.UploadImageURL(imgURL, "")
+	result, err := s.UploadImageURL(context.Background(), imgURL, "", ResourceTypeImage, UploadOptions{})
 	if err != nil {
 		t.Fatal(err)
 	}
-	t.Log(id)
+	t.Log(result)
 }
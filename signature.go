@@ -0,0 +1,53 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// signedParamExclusions lists the parameters that must never be part of the
+// string that gets signed, either because they are not sent to Cloudinary
+// as a regular parameter (file) or because they are the signature mechanism
+// itself (api_key, signature) or conveyed via the URL rather than the
+// signed payload (resource_type).
+var signedParamExclusions = map[string]bool{
+	"api_key":       true,
+	"signature":     true,
+	"file":          true,
+	"resource_type": true,
+}
+
+// signParams computes the signature Cloudinary expects for a request: the
+// non-excluded parameters are sorted alphabetically by key, joined as
+// "k1=v1&k2=v2", the API secret is appended, and the result is SHA1 hashed.
+//
+// See https://cloudinary.com/documentation/upload_images#generating_authentication_signatures
+func signParams(params map[string]string, secret string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if signedParamExclusions[k] {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+params[k])
+	}
+
+	hash := sha1.New()
+	io.WriteString(hash, strings.Join(parts, "&")+secret)
+
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
@@ -0,0 +1,70 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package cloudinary
+
+import "testing"
+
+func TestSignParams(t *testing.T) {
+	// Known-answer tests taken from Cloudinary's own documentation example
+	// (https://cloudinary.com/documentation/upload_images#generating_authentication_signatures),
+	// plus a couple of cases exercising the excluded-parameter rule.
+	cases := []struct {
+		name   string
+		params map[string]string
+		secret string
+		want   string
+	}{
+		{
+			name: "documentation example",
+			params: map[string]string{
+				"public_id": "sample_image",
+				"timestamp": "1315060510",
+			},
+			secret: "abcd",
+			want:   "b4ad47fb4e25c7bf5f92a20089f9db59bc302313",
+		},
+		{
+			name: "api_key, signature and file are excluded",
+			params: map[string]string{
+				"public_id": "sample_image",
+				"timestamp": "1315060510",
+				"api_key":   "1234567",
+				"signature": "whatever",
+				"file":      "some-base64-data",
+			},
+			secret: "abcd",
+			want:   "b4ad47fb4e25c7bf5f92a20089f9db59bc302313",
+		},
+		{
+			name: "resource_type is excluded",
+			params: map[string]string{
+				"public_id":     "sample_image",
+				"timestamp":     "1315060510",
+				"resource_type": "video",
+			},
+			secret: "abcd",
+			want:   "b4ad47fb4e25c7bf5f92a20089f9db59bc302313",
+		},
+		{
+			name: "params are sorted alphabetically regardless of insertion order",
+			params: map[string]string{
+				"timestamp": "1315060510",
+				"public_id": "sample_image",
+			},
+			secret: "abcd",
+			want:   "b4ad47fb4e25c7bf5f92a20089f9db59bc302313",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := signParams(c.params, c.secret)
+			if got != c.want {
+				t.Errorf("signParams(%v, %q) = %q, want %q", c.params, c.secret, got, c.want)
+			}
+		})
+	}
+}
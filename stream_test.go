@@ -0,0 +1,125 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package cloudinary
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// onlyReader strips any other interface (notably io.ReadSeeker) a reader
+// might implement, so tests can exercise the "size unknown" code path.
+type onlyReader struct{ io.Reader }
+
+func TestBuildHTTPRequestContentLengthForSeekableReader(t *testing.T) {
+	req, err := newRequest("http://example.invalid/upload", "key", "secret", UploadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("x"), 1<<20) // 1MiB
+	if err := req.addImageFileToRequest(bytes.NewReader(data), ""); err != nil {
+		t.Fatal(err)
+	}
+
+	httpReq, closer, err := req.buildHTTPRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closer()
+
+	if httpReq.ContentLength <= 0 {
+		t.Fatalf("expected a known positive Content-Length, got %d", httpReq.ContentLength)
+	}
+
+	n, err := io.Copy(io.Discard, httpReq.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != httpReq.ContentLength {
+		t.Errorf("streamed body length %d does not match advertised Content-Length %d", n, httpReq.ContentLength)
+	}
+}
+
+func TestBuildHTTPRequestChunkedForUnknownSizeReader(t *testing.T) {
+	req, err := newRequest("http://example.invalid/upload", "key", "secret", UploadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := onlyReader{bytes.NewReader([]byte("hello world"))}
+	if err := req.addImageFileToRequest(data, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	httpReq, closer, err := req.buildHTTPRequest(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closer()
+
+	if httpReq.ContentLength != 0 {
+		t.Errorf("expected Content-Length to be left unset (0) for an unsized reader, got %d", httpReq.ContentLength)
+	}
+}
+
+// zeroReader produces n zero bytes without ever allocating them as a
+// single block, to exercise streaming of large payloads in tests and
+// benchmarks without the test itself needing gigabytes of memory.
+type zeroReader struct{ remaining int64 }
+
+func (z *zeroReader) Read(p []byte) (int, error) {
+	if z.remaining <= 0 {
+		return 0, io.EOF
+	}
+	n := len(p)
+	if int64(n) > z.remaining {
+		n = int(z.remaining)
+	}
+	z.remaining -= int64(n)
+	return n, nil
+}
+
+// BenchmarkUploadImageFileLarge uploads a synthetic 1GB file through the
+// full request/response path and reports allocations, demonstrating that
+// memory use does not grow with the payload size now that the file is
+// streamed straight into the request body instead of buffered upfront.
+func BenchmarkUploadImageFileLarge(b *testing.B) {
+	const size = 1 << 30 // 1GB
+
+	s := &Service{
+		client:    http.Client{Transport: &discardingRoundTripper{}},
+		cloudName: "demo",
+		apiKey:    "key",
+		apiSecret: "secret",
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(size)
+	for i := 0; i < b.N; i++ {
+		if _, err := s.UploadImageFile(context.Background(), &zeroReader{remaining: size}, "", ResourceTypeImage, UploadOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// discardingRoundTripper drains and discards the request body and replies
+// with a canned UploadResult, simulating Cloudinary without a network
+// round trip.
+type discardingRoundTripper struct{}
+
+func (*discardingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, err := io.Copy(io.Discard, req.Body); err != nil {
+		return nil, err
+	}
+	body := `{"public_id":"large_file","secure_url":"https://res.cloudinary.com/demo/image/upload/large_file"}`
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader([]byte(body))),
+	}, nil
+}
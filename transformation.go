@@ -0,0 +1,121 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const baseResourceURL = "https://res.cloudinary.com"
+
+// Transformation is a fluent builder for Cloudinary's URL transformation
+// grammar, e.g. "w_300,h_200,c_fill,g_auto". Methods can be chained since
+// each one returns the receiver. Several Transformations can be applied to
+// the same asset by passing them in sequence to Service.BuildURL, which
+// joins them with "/" the way Cloudinary chains transformations.
+type Transformation struct {
+	params []string
+}
+
+// NewTransformation returns an empty Transformation ready to be configured.
+func NewTransformation() *Transformation {
+	return &Transformation{}
+}
+
+func (t *Transformation) add(format string, args ...interface{}) *Transformation {
+	t.params = append(t.params, fmt.Sprintf(format, args...))
+	return t
+}
+
+// Width sets the target width ("w_<width>").
+func (t *Transformation) Width(width int) *Transformation {
+	return t.add("w_%d", width)
+}
+
+// Height sets the target height ("h_<height>").
+func (t *Transformation) Height(height int) *Transformation {
+	return t.add("h_%d", height)
+}
+
+// Crop sets the crop mode, e.g. "fit", "fill", "crop", "thumb" ("c_<mode>").
+func (t *Transformation) Crop(mode string) *Transformation {
+	return t.add("c_%s", mode)
+}
+
+// Gravity sets the gravity used by crop modes that need one, e.g. "auto",
+// "face", "center" ("g_<gravity>").
+func (t *Transformation) Gravity(gravity string) *Transformation {
+	return t.add("g_%s", gravity)
+}
+
+// Quality sets the compression quality, e.g. "auto", "80" ("q_<quality>").
+func (t *Transformation) Quality(quality string) *Transformation {
+	return t.add("q_%s", quality)
+}
+
+// Format sets the delivery format, e.g. "jpg", "webp", "auto" ("f_<format>").
+func (t *Transformation) Format(format string) *Transformation {
+	return t.add("f_%s", format)
+}
+
+// DPR sets the target device pixel ratio, e.g. 2.0 ("dpr_<dpr>").
+func (t *Transformation) DPR(dpr float64) *Transformation {
+	return t.add("dpr_%s", strconv.FormatFloat(dpr, 'f', -1, 64))
+}
+
+// Radius sets the corner radius, e.g. "20", "max" ("r_<radius>").
+func (t *Transformation) Radius(radius string) *Transformation {
+	return t.add("r_%s", radius)
+}
+
+// Effect applies a named effect, e.g. "grayscale", "sepia" ("e_<effect>").
+func (t *Transformation) Effect(effect string) *Transformation {
+	return t.add("e_%s", effect)
+}
+
+// Overlay places another resource as an overlay ("l_<publicID>").
+func (t *Transformation) Overlay(publicID string) *Transformation {
+	return t.add("l_%s", publicID)
+}
+
+// String serializes the transformation as a single comma-separated
+// component of Cloudinary's URL transformation grammar.
+func (t *Transformation) String() string {
+	return strings.Join(t.params, ",")
+}
+
+// BuildURL builds a delivery URL for publicID as a resourceType resource,
+// applying the given transformations in sequence. Each Transformation
+// becomes its own "/"-separated path component, the way chained
+// transformations are expressed in Cloudinary URLs.
+func (s *Service) BuildURL(publicID string, resourceType ResourceType, t ...Transformation) (*url.URL, error) {
+	segments := []string{s.cloudName, string(resourceType), "upload"}
+	for _, tr := range t {
+		if str := tr.String(); str != "" {
+			segments = append(segments, str)
+		}
+	}
+	segments = append(segments, publicID)
+
+	return url.Parse(fmt.Sprintf("%s/%s", baseResourceURL, strings.Join(segments, "/")))
+}
+
+// encodeEager serializes eager transformations for the "eager" upload
+// parameter: transformations are joined with "|", matching the way
+// Cloudinary expects multiple eager derivatives to be requested at once.
+func encodeEager(t []Transformation) string {
+	parts := make([]string, 0, len(t))
+	for _, tr := range t {
+		if str := tr.String(); str != "" {
+			parts = append(parts, str)
+		}
+	}
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,69 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+package cloudinary
+
+import "testing"
+
+func TestTransformationString(t *testing.T) {
+	got := NewTransformation().Width(300).Height(200).Crop("fill").Gravity("auto").String()
+	want := "w_300,h_200,c_fill,g_auto"
+	if got != want {
+		t.Errorf("Transformation.String() = %q, want %q", got, want)
+	}
+}
+
+func TestTransformationStringEmpty(t *testing.T) {
+	if got := NewTransformation().String(); got != "" {
+		t.Errorf("empty Transformation.String() = %q, want \"\"", got)
+	}
+}
+
+func TestBuildURL(t *testing.T) {
+	s := &Service{cloudName: "demo"}
+
+	u, err := s.BuildURL("folder/sample", ResourceTypeImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://res.cloudinary.com/demo/image/upload/folder/sample"; u.String() != want {
+		t.Errorf("BuildURL() = %q, want %q", u.String(), want)
+	}
+
+	u, err = s.BuildURL("folder/sample", ResourceTypeImage,
+		*NewTransformation().Width(300).Height(200).Crop("fill"),
+		*NewTransformation().Effect("grayscale"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "https://res.cloudinary.com/demo/image/upload/w_300,h_200,c_fill/e_grayscale/folder/sample"
+	if u.String() != want {
+		t.Errorf("BuildURL() = %q, want %q", u.String(), want)
+	}
+}
+
+func TestBuildURLNonImageResourceType(t *testing.T) {
+	s := &Service{cloudName: "demo"}
+
+	u, err := s.BuildURL("folder/clip", ResourceTypeVideo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://res.cloudinary.com/demo/video/upload/folder/clip"; u.String() != want {
+		t.Errorf("BuildURL() = %q, want %q", u.String(), want)
+	}
+}
+
+func TestEncodeEager(t *testing.T) {
+	got := encodeEager([]Transformation{
+		*NewTransformation().Width(200).Height(200),
+		*NewTransformation().Width(400).Height(400),
+	})
+	want := "w_200,h_200|w_400,h_400"
+	if got != want {
+		t.Errorf("encodeEager() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// UploadOptions controls the optional parameters Cloudinary accepts on an
+// upload, beyond the file or URL being uploaded. The zero value uploads
+// with none of these set, i.e. Cloudinary's own defaults apply.
+type UploadOptions struct {
+	// PublicID assigns the public ID of the uploaded asset. If empty,
+	// Cloudinary generates one.
+	PublicID string
+	// Folder places the asset in a folder, prefixed onto PublicID.
+	Folder string
+	// Tags are attached to the asset for later filtering and search.
+	Tags []string
+	// Context is arbitrary key/value metadata attached to the asset.
+	Context map[string]string
+	// Transformation is applied to the asset as it's uploaded, replacing
+	// the original with the transformed version.
+	Transformation *Transformation
+	// Eager requests derived assets be generated at upload time instead
+	// of on first request.
+	Eager []Transformation
+	// NotificationURL receives a webhook once upload processing completes.
+	NotificationURL string
+	// Overwrite controls whether uploading to an existing PublicID
+	// replaces it. Nil leaves Cloudinary's default behavior in place.
+	Overwrite *bool
+	// UniqueFilename controls whether Cloudinary appends random
+	// characters to a generated public ID to keep it unique. Nil leaves
+	// Cloudinary's default behavior in place.
+	UniqueFilename *bool
+	// UseFilename derives the public ID from the uploaded file's name
+	// instead of generating a random one. Nil leaves Cloudinary's default
+	// behavior in place.
+	UseFilename *bool
+	// Invalidate asks Cloudinary to invalidate cached copies of the asset
+	// on its CDN after an overwrite. Nil leaves Cloudinary's default
+	// behavior in place.
+	Invalidate *bool
+	// UploadPreset names a preset configured in the Cloudinary console to
+	// supply additional defaults for this upload.
+	UploadPreset string
+}
+
+// toParams flattens o into the string-keyed parameters Cloudinary's upload
+// API expects, ready to be merged into the request's signed parameters.
+// Unset fields are omitted entirely, rather than sent as empty strings.
+func (o UploadOptions) toParams() map[string]string {
+	params := make(map[string]string)
+
+	if o.PublicID != "" {
+		params["public_id"] = o.PublicID
+	}
+	if o.Folder != "" {
+		params["folder"] = o.Folder
+	}
+	if len(o.Tags) > 0 {
+		params["tags"] = strings.Join(o.Tags, ",")
+	}
+	if len(o.Context) > 0 {
+		params["context"] = encodeContext(o.Context)
+	}
+	if o.Transformation != nil {
+		if str := o.Transformation.String(); str != "" {
+			params["transformation"] = str
+		}
+	}
+	if eagerValue := encodeEager(o.Eager); eagerValue != "" {
+		params["eager"] = eagerValue
+	}
+	if o.NotificationURL != "" {
+		params["notification_url"] = o.NotificationURL
+	}
+	if o.Overwrite != nil {
+		params["overwrite"] = strconv.FormatBool(*o.Overwrite)
+	}
+	if o.UniqueFilename != nil {
+		params["unique_filename"] = strconv.FormatBool(*o.UniqueFilename)
+	}
+	if o.UseFilename != nil {
+		params["use_filename"] = strconv.FormatBool(*o.UseFilename)
+	}
+	if o.Invalidate != nil {
+		params["invalidate"] = strconv.FormatBool(*o.Invalidate)
+	}
+	if o.UploadPreset != "" {
+		params["upload_preset"] = o.UploadPreset
+	}
+
+	return params
+}
+
+// encodeContext serializes context metadata as Cloudinary expects it on
+// upload: "key=value" pairs separated by "|", sorted for a deterministic
+// signature regardless of map iteration order.
+func encodeContext(context map[string]string) string {
+	keys := make([]string, 0, len(context))
+	for k := range context {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+"="+context[k])
+	}
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,75 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import "testing"
+
+func TestUploadOptionsToParamsOmitsUnsetFields(t *testing.T) {
+	params := UploadOptions{}.toParams()
+	if len(params) != 0 {
+		t.Errorf("zero value should produce no params, got %v", params)
+	}
+}
+
+func TestUploadOptionsToParams(t *testing.T) {
+	overwrite := true
+	useFilename := false
+
+	opts := UploadOptions{
+		PublicID:        "my_id",
+		Folder:          "avatars",
+		Tags:            []string{"a", "b"},
+		Context:         map[string]string{"caption": "hi", "alt": "there"},
+		Transformation:  NewTransformation().Width(100).Height(100),
+		Eager:           []Transformation{*NewTransformation().Crop("fill")},
+		NotificationURL: "https://example.com/hook",
+		Overwrite:       &overwrite,
+		UseFilename:     &useFilename,
+		UploadPreset:    "preset1",
+	}
+	params := opts.toParams()
+
+	want := map[string]string{
+		"public_id":        "my_id",
+		"folder":           "avatars",
+		"tags":             "a,b",
+		"context":          "alt=there|caption=hi",
+		"transformation":   "w_100,h_100",
+		"eager":            "c_fill",
+		"notification_url": "https://example.com/hook",
+		"overwrite":        "true",
+		"use_filename":     "false",
+		"upload_preset":    "preset1",
+	}
+	if len(params) != len(want) {
+		t.Fatalf("got %d params, want %d: %v", len(params), len(want), params)
+	}
+	for k, v := range want {
+		if params[k] != v {
+			t.Errorf("params[%q] = %q, want %q", k, params[k], v)
+		}
+	}
+
+	// Invalidate and UniqueFilename were left nil and must not appear.
+	if _, ok := params["invalidate"]; ok {
+		t.Error("unset Invalidate should not appear in params")
+	}
+	if _, ok := params["unique_filename"]; ok {
+		t.Error("unset UniqueFilename should not appear in params")
+	}
+}
+
+func TestEncodeContextIsSortedForDeterministicSignatures(t *testing.T) {
+	a := encodeContext(map[string]string{"z": "1", "a": "2"})
+	b := encodeContext(map[string]string{"a": "2", "z": "1"})
+	if a != b {
+		t.Errorf("encodeContext should be order-independent, got %q vs %q", a, b)
+	}
+	if a != "a=2|z=1" {
+		t.Errorf("encodeContext() = %q, want %q", a, "a=2|z=1")
+	}
+}
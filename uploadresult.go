@@ -0,0 +1,28 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+// UploadResult is Cloudinary's full response to a successful upload. See
+// https://cloudinary.com/documentation/image_upload_api_reference#upload_response
+// for the authoritative field list.
+type UploadResult struct {
+	PublicID         string   `json:"public_id"`
+	Version          uint     `json:"version"`
+	Signature        string   `json:"signature"`
+	Width            int      `json:"width"`
+	Height           int      `json:"height"`
+	Format           string   `json:"format"`
+	ResourceType     string   `json:"resource_type"`
+	CreatedAt        string   `json:"created_at"`
+	Tags             []string `json:"tags"`
+	Size             int      `json:"bytes"` // In bytes
+	Type             string   `json:"type"`
+	Etag             string   `json:"etag"`
+	URL              string   `json:"url"`
+	SecureURL        string   `json:"secure_url"`
+	OriginalFilename string   `json:"original_filename"`
+}
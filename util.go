@@ -0,0 +1,23 @@
+// Copyright 2013 Mathias Monnerville and Anthony Baillard.
+// Modified 2020 Simon Partridge & Benjamin King
+// All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cloudinary
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// randomHex returns a random hex-encoded string backed by n bytes of
+// crypto/rand, suitable for multipart boundaries and upload identifiers
+// that merely need to be unique, not cryptographically secret.
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", b), nil
+}